@@ -10,8 +10,10 @@ func main() {
 	// 기본 설정으로 Config 생성
 	config := healthcheck.NewDefaultConfig()
 
-	// Slack Webhook URL 설정
-	config.SlackWebhookURL = "https://hooks.slack.com/services/YOUR_WEBHOOK_URL"
+	// Slack Webhook 알림 설정
+	config.Notifiers = append(config.Notifiers, &healthcheck.SlackNotifier{
+		WebhookURL: "https://hooks.slack.com/services/YOUR_WEBHOOK_URL",
+	})
 
 	// 서버 유형별 프로세스 목록 설정
 	config.AddProcessType("web", []string{"nginx", "prometheus"})
@@ -28,5 +30,5 @@ func main() {
 	// 헬스 체크 실행
 	report := config.RunCheck()
 	fmt.Println("Health check completed!")
-	fmt.Println(report)
+	fmt.Println(report.Text())
 }
@@ -0,0 +1,108 @@
+// Command healthcheck runs healthcheck from a declarative YAML/TOML config
+// file via healthcheck.LoadConfig. It supports running a one-shot check,
+// validating a config file, and serving a Prometheus exporter with hot
+// reload.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	healthcheck "github.com/SundaePorkCutlet/healthCheck"
+	"github.com/SundaePorkCutlet/healthCheck/exporter"
+	"github.com/urfave/cli/v2"
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "healthcheck",
+		Usage: "run host health checks from a declarative config file",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Aliases: []string{"c"},
+				Value:   "healthcheck.yaml",
+				Usage:   "path to a YAML or TOML config file",
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:   "run",
+				Usage:  "run every configured check once and print the report",
+				Action: runCommand,
+			},
+			{
+				Name:   "validate",
+				Usage:  "load the config file and report whether it parses",
+				Action: validateCommand,
+			},
+			{
+				Name:  "serve",
+				Usage: "serve a Prometheus exporter, reloading the config on change",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "listen-addr",
+						Value: ":9105",
+						Usage: "address to serve /metrics on",
+					},
+				},
+				Action: serveCommand,
+			},
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintf(os.Stderr, "healthcheck: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runCommand(cliCtx *cli.Context) error {
+	config, err := healthcheck.LoadConfig(cliCtx.String("config"))
+	if err != nil {
+		return err
+	}
+	defer config.Close()
+
+	report := config.RunCheck()
+	fmt.Println(report.Text())
+	return nil
+}
+
+func validateCommand(cliCtx *cli.Context) error {
+	path := cliCtx.String("config")
+	config, err := healthcheck.LoadConfig(path)
+	if err != nil {
+		return fmt.Errorf("%s: invalid: %w", path, err)
+	}
+	defer config.Close()
+
+	fmt.Printf("%s: ok (%d server(s) configured)\n", path, len(config.Servers))
+	return nil
+}
+
+func serveCommand(cliCtx *cli.Context) error {
+	path := cliCtx.String("config")
+	config, err := healthcheck.LoadConfig(path)
+	if err != nil {
+		return err
+	}
+	defer config.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		if err := config.Watch(ctx, path); err != nil && err != context.Canceled {
+			fmt.Fprintf(os.Stderr, "config watcher stopped: %v\n", err)
+		}
+	}()
+
+	listenAddr := cliCtx.String("listen-addr")
+	exp := exporter.New(config)
+	http.Handle("/metrics", exp.Handler())
+	fmt.Printf("healthcheck serving on %s\n", listenAddr)
+	return http.ListenAndServe(listenAddr, nil)
+}
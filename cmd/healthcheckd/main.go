@@ -0,0 +1,124 @@
+// Command healthcheckd runs healthcheck as a long-lived Prometheus exporter:
+// it serves /metrics on a scrape port and, if configured, also pushes
+// reports to notifiers whenever the overall severity changes.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	healthcheck "github.com/SundaePorkCutlet/healthCheck"
+	"github.com/SundaePorkCutlet/healthCheck/exporter"
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig is the on-disk YAML shape for healthcheckd. It intentionally
+// mirrors only the fields this binary needs; healthcheck.LoadConfig is the
+// full declarative loader.
+type fileConfig struct {
+	ListenAddr      string              `yaml:"listen_addr"`
+	NotifyInterval  time.Duration       `yaml:"notify_interval"`
+	SlackWebhookURL string              `yaml:"slack_webhook_url"`
+	Servers         []serverFileConfig  `yaml:"servers"`
+	Thresholds      map[string]float64  `yaml:"thresholds"`
+	ProcessMap      map[string][]string `yaml:"process_map"`
+}
+
+type serverFileConfig struct {
+	IP        string   `yaml:"ip"`
+	Username  string   `yaml:"username"`
+	Password  string   `yaml:"password"`
+	Processes []string `yaml:"processes"`
+	Type      string   `yaml:"type"`
+}
+
+func main() {
+	configPath := flag.String("config", "healthcheckd.yaml", "path to a YAML config file")
+	flag.Parse()
+
+	fc, err := loadFileConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	config := buildConfig(fc)
+
+	if fc.SlackWebhookURL != "" && fc.NotifyInterval > 0 {
+		go watchForStateChanges(config, fc.SlackWebhookURL, fc.NotifyInterval)
+	}
+
+	listenAddr := fc.ListenAddr
+	if listenAddr == "" {
+		listenAddr = ":9105"
+	}
+
+	exp := exporter.New(config)
+	http.Handle("/metrics", exp.Handler())
+	fmt.Printf("healthcheckd listening on %s\n", listenAddr)
+	if err := http.ListenAndServe(listenAddr, nil); err != nil {
+		fmt.Fprintf(os.Stderr, "server error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// loadFileConfig reads and parses the YAML config at path.
+func loadFileConfig(path string) (*fileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var fc fileConfig
+	if err := yaml.Unmarshal(data, &fc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	return &fc, nil
+}
+
+// buildConfig turns a fileConfig into a healthcheck.Config with default
+// collectors, layering the file's servers, thresholds, and process map on
+// top of NewDefaultConfig.
+func buildConfig(fc *fileConfig) *healthcheck.Config {
+	config := healthcheck.NewDefaultConfig()
+
+	for _, s := range fc.Servers {
+		config.AddServer(s.IP, s.Username, s.Password, s.Processes, s.Type)
+	}
+	for typeName, processes := range fc.ProcessMap {
+		config.AddProcessType(typeName, processes)
+	}
+	for metric, threshold := range fc.Thresholds {
+		config.SetThreshold(metric, threshold)
+	}
+
+	return config
+}
+
+// watchForStateChanges periodically runs config.RunCheck and notifies
+// webhookURL only when the report's overall severity changes, so a steady
+// stream of OK reports doesn't spam the channel.
+func watchForStateChanges(config *healthcheck.Config, webhookURL string, interval time.Duration) {
+	notifier := &healthcheck.SlackNotifier{WebhookURL: webhookURL}
+	lastSeverity := healthcheck.SeverityOK
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		report := config.RunCheck()
+		severity := report.MaxSeverity()
+		if severity == lastSeverity {
+			continue
+		}
+		lastSeverity = severity
+
+		if err := notifier.Notify(context.Background(), report); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to notify on state change: %v\n", err)
+		}
+	}
+}
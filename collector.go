@@ -0,0 +1,292 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/mem"
+	"golang.org/x/crypto/ssh"
+)
+
+// MetricStatus is the outcome of evaluating a MetricSample against its
+// configured threshold.
+type MetricStatus string
+
+const (
+	StatusOK   MetricStatus = "OK"
+	StatusWarn MetricStatus = "WARN"
+	StatusFail MetricStatus = "FAIL"
+)
+
+// MetricSample is a single typed measurement produced by a MetricCollector.
+type MetricSample struct {
+	Name   string       // e.g. "CPU Idle", "Memory Used"
+	Value  float64      // measured value
+	Unit   string       // e.g. "%"
+	Status MetricStatus // OK, WARN, or FAIL once evaluated against a threshold
+	Detail string       // optional free-form text, used by ShellCollector
+}
+
+// SSHClientGetter returns an SSH client for server, suitable for a single
+// command's use. checkStatusContext passes Config.getSSHClient, so every
+// collector shares the same pooled, retrying connection per server instead
+// of dialing its own.
+type SSHClientGetter func(ctx context.Context, server ServerConfig) (*ssh.Client, error)
+
+// MetricCollector gathers one or more MetricSamples for a server. Built-in
+// collectors (CPUCollector, MemoryCollector, DiskCollector, NetworkCollector)
+// sample the local host directly (via gopsutil, or a TCP dial for
+// NetworkCollector), and fall back to running the
+// equivalent shell command over SSH for any other server, via getClient;
+// ShellCollector remains available for arbitrary remote, command-based
+// checks.
+type MetricCollector interface {
+	// Name identifies the collector in reports, e.g. on a failed Collect.
+	Name() string
+	// Collect gathers samples for the given server, evaluating them against
+	// thresholds keyed by sample name. getClient supplies a pooled SSH client
+	// for non-local servers; collectors must not close it.
+	Collect(ctx context.Context, server ServerConfig, thresholds map[string]float64, getClient SSHClientGetter) ([]MetricSample, error)
+}
+
+// isLocalServer reports whether server refers to the monitoring host itself,
+// in which case checks run locally instead of over SSH.
+func isLocalServer(server ServerConfig) bool {
+	return server.IP == "localhost" || server.IP == "127.0.0.1"
+}
+
+// CPUCollector reports CPU idle percentage, via gopsutil for the local host
+// or `top` over SSH for a remote server.
+type CPUCollector struct {
+	// SampleInterval controls how long cpu.Percent observes before returning.
+	SampleInterval time.Duration
+}
+
+// NewCPUCollector returns a CPUCollector with a sensible sampling interval.
+func NewCPUCollector() *CPUCollector {
+	return &CPUCollector{SampleInterval: time.Second}
+}
+
+func (c *CPUCollector) Name() string { return "CPU Usage" }
+
+func (c *CPUCollector) Collect(ctx context.Context, server ServerConfig, thresholds map[string]float64, getClient SSHClientGetter) ([]MetricSample, error) {
+	idle, err := c.sample(ctx, server, getClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CPU usage for %s: %w", server.IP, err)
+	}
+
+	status := StatusOK
+	if idle <= thresholds["CPU Idle"] {
+		status = StatusWarn
+	}
+
+	return []MetricSample{{Name: "CPU Idle", Value: idle, Unit: "%", Status: status}}, nil
+}
+
+func (c *CPUCollector) sample(ctx context.Context, server ServerConfig, getClient SSHClientGetter) (float64, error) {
+	if isLocalServer(server) {
+		percents, err := cpu.PercentWithContext(ctx, c.SampleInterval, false)
+		if err != nil {
+			return 0, err
+		}
+		if len(percents) == 0 {
+			return 0, fmt.Errorf("no CPU usage samples returned")
+		}
+		return 100 - percents[0], nil
+	}
+
+	client, err := getClient(ctx, server)
+	if err != nil {
+		return 0, err
+	}
+
+	output, err := runCommand(ctx, client, `top -bn1 | grep 'Cpu(s)' | sed 's/.*, *\([0-9.]*\)%* id.*/\1/'`, false)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(strings.TrimSpace(output), 64)
+}
+
+// MemoryCollector reports used memory percentage, via gopsutil for the local
+// host or `free` over SSH for a remote server.
+type MemoryCollector struct{}
+
+// NewMemoryCollector returns a MemoryCollector.
+func NewMemoryCollector() *MemoryCollector { return &MemoryCollector{} }
+
+func (c *MemoryCollector) Name() string { return "Memory Usage" }
+
+func (c *MemoryCollector) Collect(ctx context.Context, server ServerConfig, thresholds map[string]float64, getClient SSHClientGetter) ([]MetricSample, error) {
+	used, err := c.sample(ctx, server, getClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read memory usage for %s: %w", server.IP, err)
+	}
+
+	status := StatusOK
+	if used >= thresholds["Memory Used"] {
+		status = StatusWarn
+	}
+
+	return []MetricSample{{Name: "Memory Used", Value: used, Unit: "%", Status: status}}, nil
+}
+
+func (c *MemoryCollector) sample(ctx context.Context, server ServerConfig, getClient SSHClientGetter) (float64, error) {
+	if isLocalServer(server) {
+		vm, err := mem.VirtualMemoryWithContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return vm.UsedPercent, nil
+	}
+
+	client, err := getClient(ctx, server)
+	if err != nil {
+		return 0, err
+	}
+
+	output, err := runCommand(ctx, client, `free | awk 'NR==2{printf "%.1f", $3*100/$2}'`, false)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(strings.TrimSpace(output), 64)
+}
+
+// DiskCollector reports used disk percentage for Path, via gopsutil for the
+// local host or `df` over SSH for a remote server. Path defaults to "/" on
+// Unix-like systems and "C:\\" on Windows.
+type DiskCollector struct {
+	Path string
+}
+
+// NewDiskCollector returns a DiskCollector for the OS's default root path.
+func NewDiskCollector() *DiskCollector {
+	path := "/"
+	if runtime.GOOS == "windows" {
+		path = `C:\`
+	}
+	return &DiskCollector{Path: path}
+}
+
+func (c *DiskCollector) Name() string { return "Disk Usage" }
+
+func (c *DiskCollector) Collect(ctx context.Context, server ServerConfig, thresholds map[string]float64, getClient SSHClientGetter) ([]MetricSample, error) {
+	used, err := c.sample(ctx, server, getClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read disk usage for %s on %s: %w", c.Path, server.IP, err)
+	}
+
+	status := StatusOK
+	if used >= thresholds["Disk Used"] {
+		status = StatusWarn
+	}
+
+	return []MetricSample{{Name: "Disk Used", Value: used, Unit: "%", Status: status}}, nil
+}
+
+func (c *DiskCollector) sample(ctx context.Context, server ServerConfig, getClient SSHClientGetter) (float64, error) {
+	if isLocalServer(server) {
+		usage, err := disk.UsageWithContext(ctx, c.Path)
+		if err != nil {
+			return 0, err
+		}
+		return usage.UsedPercent, nil
+	}
+
+	client, err := getClient(ctx, server)
+	if err != nil {
+		return 0, err
+	}
+
+	cmd := fmt.Sprintf(`df -P %s | awk 'NR==2{print $5}' | tr -d '%%'`, c.Path)
+	output, err := runCommand(ctx, client, cmd, false)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(strings.TrimSpace(output), 64)
+}
+
+// NetworkCollector reports basic connectivity from the checked host to
+// Target: a TCP dial for the local host, or a ping run over SSH for a remote
+// server (mirroring the dial's intent, since the remote host is what needs
+// to reach Target, not the monitoring host).
+type NetworkCollector struct {
+	Target  string // host:port to dial/ping, e.g. "8.8.8.8:53"
+	Timeout time.Duration
+}
+
+// NewNetworkCollector returns a NetworkCollector that checks connectivity to
+// a public DNS resolver.
+func NewNetworkCollector() *NetworkCollector {
+	return &NetworkCollector{Target: "8.8.8.8:53", Timeout: 3 * time.Second}
+}
+
+func (c *NetworkCollector) Name() string { return "Network Check" }
+
+func (c *NetworkCollector) Collect(ctx context.Context, server ServerConfig, thresholds map[string]float64, getClient SSHClientGetter) ([]MetricSample, error) {
+	if !c.sample(ctx, server, getClient) {
+		return []MetricSample{{Name: "Network Check", Value: 0, Status: StatusFail, Detail: "Network Issue"}}, nil
+	}
+	return []MetricSample{{Name: "Network Check", Value: 1, Status: StatusOK, Detail: "Network is OK"}}, nil
+}
+
+func (c *NetworkCollector) sample(ctx context.Context, server ServerConfig, getClient SSHClientGetter) bool {
+	if isLocalServer(server) {
+		dialCtx, cancel := context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+
+		conn, err := (&net.Dialer{}).DialContext(dialCtx, "tcp", c.Target)
+		if err != nil {
+			return false
+		}
+		defer conn.Close()
+		return true
+	}
+
+	client, err := getClient(ctx, server)
+	if err != nil {
+		return false
+	}
+
+	host := c.Target
+	if h, _, err := net.SplitHostPort(c.Target); err == nil {
+		host = h
+	}
+
+	cmd := fmt.Sprintf(`ping -c 1 -W %d %s > /dev/null && echo ok || echo fail`, int(c.Timeout.Seconds()), host)
+	output, err := runCommand(ctx, client, cmd, false)
+	return err == nil && strings.TrimSpace(output) == "ok"
+}
+
+// ShellCollector runs an arbitrary shell command on the server (locally, or
+// remotely over SSH) and reports its trimmed output as a Detail string with
+// Status always OK; it exists so existing AddCommand users can migrate to
+// the MetricCollector interface without rewriting their commands.
+type ShellCollector struct {
+	Label   string
+	Command string
+}
+
+func (c *ShellCollector) Name() string { return c.Label }
+
+func (c *ShellCollector) Collect(ctx context.Context, server ServerConfig, thresholds map[string]float64, getClient SSHClientGetter) ([]MetricSample, error) {
+	isLocal := isLocalServer(server)
+
+	client, err := getClient(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := runCommand(ctx, client, c.Command, isLocal)
+	if err != nil {
+		return nil, err
+	}
+
+	return []MetricSample{{Name: c.Label, Status: StatusOK, Detail: output}}, nil
+}
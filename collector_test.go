@@ -0,0 +1,104 @@
+package healthcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// localServer is a ServerConfig that routes every collector to its local
+// sampling path (gopsutil, or a TCP dial for NetworkCollector).
+var localServer = ServerConfig{IP: "localhost"}
+
+func TestCPUCollectorThresholds(t *testing.T) {
+	c := NewCPUCollector()
+
+	// CPU idle can never exceed 100%, so a threshold above that always warns.
+	samples, err := c.Collect(context.Background(), localServer, map[string]float64{"CPU Idle": 1000}, nil)
+	if err != nil {
+		t.Fatalf("Collect() error: %v", err)
+	}
+	if samples[0].Status != StatusWarn {
+		t.Errorf("Status = %v, want WARN with an unreachable threshold", samples[0].Status)
+	}
+
+	// CPU idle can never be negative, so a threshold below that never warns.
+	samples, err = c.Collect(context.Background(), localServer, map[string]float64{"CPU Idle": -1}, nil)
+	if err != nil {
+		t.Fatalf("Collect() error: %v", err)
+	}
+	if samples[0].Status != StatusOK {
+		t.Errorf("Status = %v, want OK with an always-satisfied threshold", samples[0].Status)
+	}
+}
+
+func TestMemoryCollectorThresholds(t *testing.T) {
+	c := NewMemoryCollector()
+
+	samples, err := c.Collect(context.Background(), localServer, map[string]float64{"Memory Used": -1}, nil)
+	if err != nil {
+		t.Fatalf("Collect() error: %v", err)
+	}
+	if samples[0].Status != StatusWarn {
+		t.Errorf("Status = %v, want WARN with an always-exceeded threshold", samples[0].Status)
+	}
+
+	samples, err = c.Collect(context.Background(), localServer, map[string]float64{"Memory Used": 1000}, nil)
+	if err != nil {
+		t.Fatalf("Collect() error: %v", err)
+	}
+	if samples[0].Status != StatusOK {
+		t.Errorf("Status = %v, want OK with an unreachable threshold", samples[0].Status)
+	}
+}
+
+func TestDiskCollectorThresholds(t *testing.T) {
+	c := NewDiskCollector()
+
+	samples, err := c.Collect(context.Background(), localServer, map[string]float64{"Disk Used": -1}, nil)
+	if err != nil {
+		t.Fatalf("Collect() error: %v", err)
+	}
+	if samples[0].Status != StatusWarn {
+		t.Errorf("Status = %v, want WARN with an always-exceeded threshold", samples[0].Status)
+	}
+
+	samples, err = c.Collect(context.Background(), localServer, map[string]float64{"Disk Used": 1000}, nil)
+	if err != nil {
+		t.Fatalf("Collect() error: %v", err)
+	}
+	if samples[0].Status != StatusOK {
+		t.Errorf("Status = %v, want OK with an unreachable threshold", samples[0].Status)
+	}
+}
+
+func TestNetworkCollectorUnreachable(t *testing.T) {
+	// TEST-NET-1 (RFC 5737) is guaranteed non-routable, so the dial reliably
+	// fails without depending on real network conditions.
+	c := &NetworkCollector{Target: "192.0.2.1:1", Timeout: 100 * time.Millisecond}
+
+	samples, err := c.Collect(context.Background(), localServer, nil, nil)
+	if err != nil {
+		t.Fatalf("Collect() error: %v", err)
+	}
+	if samples[0].Status != StatusFail || samples[0].Value != 0 {
+		t.Errorf("got %+v, want a failed check with Value 0", samples[0])
+	}
+}
+
+func TestIsLocalServer(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"localhost", true},
+		{"127.0.0.1", true},
+		{"10.0.0.5", false},
+	}
+
+	for _, tt := range tests {
+		if got := isLocalServer(ServerConfig{IP: tt.ip}); got != tt.want {
+			t.Errorf("isLocalServer(%q) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
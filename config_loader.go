@@ -0,0 +1,338 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/rs/zerolog"
+	"gopkg.in/yaml.v3"
+)
+
+// fileSchema is the on-disk shape of a declarative healthcheck config,
+// parsed from YAML or TOML depending on the file extension.
+type fileSchema struct {
+	LogLevel         string              `yaml:"log_level" toml:"log_level"`
+	Concurrency      int                 `yaml:"concurrency" toml:"concurrency"`
+	PerServerTimeout string              `yaml:"per_server_timeout" toml:"per_server_timeout"`
+	MaxRetries       int                 `yaml:"max_retries" toml:"max_retries"`
+	RetryBaseDelay   string              `yaml:"retry_base_delay" toml:"retry_base_delay"`
+	Thresholds       map[string]float64  `yaml:"thresholds" toml:"thresholds"`
+	ProcessMap       map[string][]string `yaml:"process_map" toml:"process_map"`
+	Collectors       []string            `yaml:"collectors" toml:"collectors"`
+	Commands         map[string]string   `yaml:"commands" toml:"commands"`
+	Servers          []fileServer        `yaml:"servers" toml:"servers"`
+	Notifiers        []fileNotifier      `yaml:"notifiers" toml:"notifiers"`
+}
+
+// builtinCollectors maps the names usable in a config file's "collectors"
+// list to the MetricCollector they build, so a declarative config can select
+// a subset of NewDefaultConfig's defaults, reorder them, or disable them
+// entirely with an empty list.
+var builtinCollectors = map[string]func() MetricCollector{
+	"cpu":     func() MetricCollector { return NewCPUCollector() },
+	"memory":  func() MetricCollector { return NewMemoryCollector() },
+	"disk":    func() MetricCollector { return NewDiskCollector() },
+	"network": func() MetricCollector { return NewNetworkCollector() },
+}
+
+// buildCollectors resolves a "collectors" list into MetricCollectors.
+func buildCollectors(names []string) ([]MetricCollector, error) {
+	collectors := make([]MetricCollector, 0, len(names))
+	for _, name := range names {
+		build, ok := builtinCollectors[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown collector %q (use cpu, memory, disk, or network)", name)
+		}
+		collectors = append(collectors, build())
+	}
+	return collectors, nil
+}
+
+// fileServer is a single entry under the top-level "servers" key.
+type fileServer struct {
+	IP                   string   `yaml:"ip" toml:"ip"`
+	Username             string   `yaml:"username" toml:"username"`
+	Password             string   `yaml:"password" toml:"password"`
+	PasswordFile         string   `yaml:"password_file" toml:"password_file"`
+	PrivateKeyPath       string   `yaml:"private_key_path" toml:"private_key_path"`
+	PrivateKeyPassphrase string   `yaml:"private_key_passphrase" toml:"private_key_passphrase"`
+	UseAgent             bool     `yaml:"use_agent" toml:"use_agent"`
+	KnownHostsPath       string   `yaml:"known_hosts_path" toml:"known_hosts_path"`
+	Processes            []string `yaml:"processes" toml:"processes"`
+	Type                 string   `yaml:"type" toml:"type"`
+}
+
+// fileNotifier is a single entry under the top-level "notifiers" key. Type
+// selects which Notifier implementation to build; the remaining fields are
+// interpreted according to Type.
+type fileNotifier struct {
+	Type        string   `yaml:"type" toml:"type"`
+	MinSeverity string   `yaml:"min_severity" toml:"min_severity"`
+	WebhookURL  string   `yaml:"webhook_url" toml:"webhook_url"`
+	RoutingKey  string   `yaml:"routing_key" toml:"routing_key"`
+	Host        string   `yaml:"host" toml:"host"`
+	Port        int      `yaml:"port" toml:"port"`
+	Username    string   `yaml:"username" toml:"username"`
+	Password    string   `yaml:"password" toml:"password"`
+	From        string   `yaml:"from" toml:"from"`
+	To          []string `yaml:"to" toml:"to"`
+}
+
+// envVarPattern matches ${VAR}-style references for interpolation. Plain
+// $VAR is intentionally left alone, since config values (e.g. passwords)
+// may legitimately contain a literal "$".
+var envVarPattern = regexp.MustCompile(`\$\{(\w+)\}`)
+
+// LoadConfig reads a YAML (.yaml/.yml) or TOML (.toml) file at path and
+// builds a Config from it. ${ENV_VAR} references anywhere in the file are
+// interpolated from the environment before parsing, and a server's
+// password_file, if set, is read and trimmed in place of a literal password
+// so secrets don't need to live in the config file itself.
+func LoadConfig(path string) (*Config, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %s: %w", path, err)
+	}
+	raw = []byte(expandEnv(string(raw)))
+
+	var fc fileSchema
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(raw, &fc); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML config: %w", err)
+		}
+	case ".toml":
+		if err := toml.Unmarshal(raw, &fc); err != nil {
+			return nil, fmt.Errorf("failed to parse TOML config: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config extension %q (use .yaml, .yml, or .toml)", ext)
+	}
+
+	return fc.toConfig()
+}
+
+// expandEnv replaces every ${VAR} in s with the value of the VAR
+// environment variable (empty if unset).
+func expandEnv(s string) string {
+	return envVarPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := envVarPattern.FindStringSubmatch(match)[1]
+		return os.Getenv(name)
+	})
+}
+
+// toConfig builds a Config from a parsed fileSchema, starting from
+// NewDefaultConfig's collectors and overlaying the file's settings.
+func (fc *fileSchema) toConfig() (*Config, error) {
+	config := NewDefaultConfig()
+	config.Servers = nil
+
+	if fc.LogLevel != "" {
+		level, err := zerolog.ParseLevel(fc.LogLevel)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log_level %q: %w", fc.LogLevel, err)
+		}
+		config.LogLevel = level
+	}
+	if fc.Concurrency > 0 {
+		config.Concurrency = fc.Concurrency
+	}
+	if fc.PerServerTimeout != "" {
+		d, err := time.ParseDuration(fc.PerServerTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid per_server_timeout %q: %w", fc.PerServerTimeout, err)
+		}
+		config.PerServerTimeout = d
+	}
+	if fc.MaxRetries > 0 {
+		config.MaxRetries = fc.MaxRetries
+	}
+	if fc.RetryBaseDelay != "" {
+		d, err := time.ParseDuration(fc.RetryBaseDelay)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry_base_delay %q: %w", fc.RetryBaseDelay, err)
+		}
+		config.RetryBaseDelay = d
+	}
+
+	for metric, threshold := range fc.Thresholds {
+		config.Thresholds[metric] = threshold
+	}
+	for typeName, processes := range fc.ProcessMap {
+		config.ProcessMap[typeName] = processes
+	}
+
+	if fc.Collectors != nil {
+		collectors, err := buildCollectors(fc.Collectors)
+		if err != nil {
+			return nil, err
+		}
+		config.Collectors = collectors
+	}
+	for name, command := range fc.Commands {
+		config.AddCommand(name, command)
+	}
+
+	for _, s := range fc.Servers {
+		server, err := s.toServerConfig()
+		if err != nil {
+			return nil, err
+		}
+		config.Servers = append(config.Servers, server)
+	}
+
+	for _, n := range fc.Notifiers {
+		notifier, err := n.build()
+		if err != nil {
+			return nil, err
+		}
+		config.Notifiers = append(config.Notifiers, notifier)
+	}
+
+	return config, nil
+}
+
+// toServerConfig builds a ServerConfig, resolving password_file if set.
+func (s *fileServer) toServerConfig() (ServerConfig, error) {
+	password := s.Password
+	if s.PasswordFile != "" {
+		data, err := os.ReadFile(s.PasswordFile)
+		if err != nil {
+			return ServerConfig{}, fmt.Errorf("failed to read password_file for %s: %w", s.IP, err)
+		}
+		password = strings.TrimSpace(string(data))
+	}
+
+	return ServerConfig{
+		IP:                   s.IP,
+		Username:             s.Username,
+		Password:             password,
+		PrivateKeyPath:       s.PrivateKeyPath,
+		PrivateKeyPassphrase: s.PrivateKeyPassphrase,
+		UseAgent:             s.UseAgent,
+		KnownHostsPath:       s.KnownHostsPath,
+		Processes:            s.Processes,
+		Type:                 s.Type,
+	}, nil
+}
+
+// build constructs the Notifier described by a fileNotifier entry.
+func (n *fileNotifier) build() (Notifier, error) {
+	severity, err := parseSeverity(n.MinSeverity)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(n.Type) {
+	case "slack":
+		return &SlackNotifier{WebhookURL: n.WebhookURL, MinSeverity: severity}, nil
+	case "discord":
+		return &DiscordNotifier{WebhookURL: n.WebhookURL, MinSeverity: severity}, nil
+	case "teams":
+		return &TeamsNotifier{WebhookURL: n.WebhookURL, MinSeverity: severity}, nil
+	case "email":
+		return &EmailNotifier{
+			Host: n.Host, Port: n.Port, Username: n.Username, Password: n.Password,
+			From: n.From, To: n.To, MinSeverity: severity,
+		}, nil
+	case "pagerduty":
+		return &PagerDutyNotifier{RoutingKey: n.RoutingKey, MinSeverity: severity}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier type %q", n.Type)
+	}
+}
+
+// parseSeverity parses a notifier's min_severity string, defaulting to
+// SeverityOK when empty.
+func parseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "", "ok":
+		return SeverityOK, nil
+	case "warn":
+		return SeverityWarn, nil
+	case "fail":
+		return SeverityFail, nil
+	default:
+		return 0, fmt.Errorf("unknown severity %q (use ok, warn, or fail)", s)
+	}
+}
+
+// Watch reloads the Config from path whenever the file changes, swapping in
+// the new settings under the Config's lock without dropping checks already in
+// flight: a run keeps the server list it snapshotted at its start, while
+// servers whose checks start after the swap use the reloaded collectors,
+// thresholds and process lists. It blocks until ctx is cancelled or the
+// watcher's channels close; watcher errors are logged and watching continues.
+func (c *Config) Watch(ctx context.Context, path string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create config watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	dir := filepath.Dir(path)
+	if err := watcher.Add(dir); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+
+	logger := newLogger(c.LogLevel)
+	target := filepath.Clean(path)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != target {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+
+			reloaded, err := LoadConfig(path)
+			if err != nil {
+				logger.Error().Err(err).Str("path", path).Msg("failed to reload config")
+				continue
+			}
+			c.applyReload(reloaded)
+			logger.Info().Str("path", path).Msg("reloaded config")
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Error().Err(err).Msg("config watcher error")
+		}
+	}
+}
+
+// applyReload swaps in another Config's mutable settings under c's lock. The
+// SSH connection pool is left untouched so existing connections survive a
+// reload.
+func (c *Config) applyReload(reloaded *Config) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Servers = reloaded.Servers
+	c.ProcessMap = reloaded.ProcessMap
+	c.Collectors = reloaded.Collectors
+	c.Notifiers = reloaded.Notifiers
+	c.Thresholds = reloaded.Thresholds
+	c.LogLevel = reloaded.LogLevel
+	c.Concurrency = reloaded.Concurrency
+	c.PerServerTimeout = reloaded.PerServerTimeout
+	c.MaxRetries = reloaded.MaxRetries
+	c.RetryBaseDelay = reloaded.RetryBaseDelay
+}
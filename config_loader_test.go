@@ -0,0 +1,94 @@
+package healthcheck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("HEALTHCHECK_TEST_VAR", "secret123")
+
+	got := expandEnv("password: ${HEALTHCHECK_TEST_VAR}\nother: ${UNSET_HEALTHCHECK_VAR}")
+	want := "password: secret123\nother: "
+	if got != want {
+		t.Errorf("expandEnv() = %q, want %q", got, want)
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Severity
+		wantErr bool
+	}{
+		{"", SeverityOK, false},
+		{"ok", SeverityOK, false},
+		{"warn", SeverityWarn, false},
+		{"WARN", SeverityWarn, false},
+		{"fail", SeverityFail, false},
+		{"critical", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSeverity(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSeverity(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got != tt.want {
+			t.Errorf("parseSeverity(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfigYAMLWithEnvAndPasswordFile(t *testing.T) {
+	t.Setenv("HEALTHCHECK_TEST_USER", "alice")
+
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "password.txt")
+	if err := os.WriteFile(passwordFile, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatalf("failed to write password file: %v", err)
+	}
+
+	configPath := filepath.Join(dir, "config.yaml")
+	contents := "collectors: []\n" +
+		"servers:\n" +
+		"  - ip: 127.0.0.1\n" +
+		"    username: ${HEALTHCHECK_TEST_USER}\n" +
+		"    password_file: " + passwordFile + "\n"
+	if err := os.WriteFile(configPath, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error: %v", err)
+	}
+
+	if len(config.Servers) != 1 {
+		t.Fatalf("got %d servers, want 1", len(config.Servers))
+	}
+	server := config.Servers[0]
+	if server.Username != "alice" {
+		t.Errorf("Username = %q, want %q (from ${HEALTHCHECK_TEST_USER})", server.Username, "alice")
+	}
+	if server.Password != "s3cret" {
+		t.Errorf("Password = %q, want %q (trimmed from password_file)", server.Password, "s3cret")
+	}
+	if len(config.Collectors) != 0 {
+		t.Errorf("Collectors = %d, want 0 (collectors: [] should disable defaults)", len(config.Collectors))
+	}
+}
+
+func TestLoadConfigRejectsUnknownExtension(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.ini")
+	if err := os.WriteFile(path, []byte("x=1"), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	if _, err := LoadConfig(path); err == nil {
+		t.Error("LoadConfig() with an unsupported extension should error")
+	}
+}
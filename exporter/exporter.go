@@ -0,0 +1,97 @@
+// Package exporter turns a healthcheck.Config into a pull-based Prometheus
+// exporter: each scrape runs a fresh health check and reports the result as
+// gauges. The check runs through RunCheckContext, so each scrape's report is
+// also delivered to any Notifiers set on the Config.
+package exporter
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	healthcheck "github.com/SundaePorkCutlet/healthCheck"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	cpuIdleDesc = prometheus.NewDesc(
+		"healthcheck_cpu_idle_percent", "CPU idle percentage.", []string{"server"}, nil)
+	memUsedDesc = prometheus.NewDesc(
+		"healthcheck_memory_used_percent", "Memory used percentage.", []string{"server"}, nil)
+	diskUsedDesc = prometheus.NewDesc(
+		"healthcheck_disk_used_percent", "Disk used percentage.", []string{"server"}, nil)
+	processUpDesc = prometheus.NewDesc(
+		"healthcheck_process_up", "1 if the process is running, else 0.", []string{"server", "process"}, nil)
+	checkDurationDesc = prometheus.NewDesc(
+		"healthcheck_check_duration_seconds", "Duration of a server's check run.", []string{"server"}, nil)
+	sshUpDesc = prometheus.NewDesc(
+		"healthcheck_ssh_up", "1 if the SSH connection to the server succeeded, else 0.", []string{"server"}, nil)
+)
+
+// Exporter runs health checks against a healthcheck.Config on demand and
+// exposes the result to Prometheus.
+type Exporter struct {
+	config *healthcheck.Config
+}
+
+// New returns an Exporter that checks config's servers on every scrape.
+func New(config *healthcheck.Config) *Exporter {
+	return &Exporter{config: config}
+}
+
+// Handler returns an http.Handler suitable for http.ListenAndServe that
+// serves Prometheus metrics, running a fresh health check on every scrape.
+func (e *Exporter) Handler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(&scrapeCollector{config: e.config})
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// scrapeCollector implements prometheus.Collector, running config.RunCheckContext
+// every time it is collected (i.e. on every scrape).
+type scrapeCollector struct {
+	config *healthcheck.Config
+}
+
+func (s *scrapeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cpuIdleDesc
+	ch <- memUsedDesc
+	ch <- diskUsedDesc
+	ch <- processUpDesc
+	ch <- checkDurationDesc
+	ch <- sshUpDesc
+}
+
+func (s *scrapeCollector) Collect(ch chan<- prometheus.Metric) {
+	report := s.config.RunCheckContext(context.Background())
+
+	for _, server := range report.Servers {
+		ch <- prometheus.MustNewConstMetric(checkDurationDesc, prometheus.GaugeValue, server.Duration.Seconds(), server.Server)
+
+		sshUp := 1.0
+		for _, check := range server.Checks {
+			switch {
+			case check.Metric == "SSH Connection":
+				if check.Status == healthcheck.StatusFail {
+					sshUp = 0
+				}
+			case check.Metric == "CPU Idle":
+				ch <- prometheus.MustNewConstMetric(cpuIdleDesc, prometheus.GaugeValue, check.Value, server.Server)
+			case check.Metric == "Memory Used":
+				ch <- prometheus.MustNewConstMetric(memUsedDesc, prometheus.GaugeValue, check.Value, server.Server)
+			case check.Metric == "Disk Used":
+				ch <- prometheus.MustNewConstMetric(diskUsedDesc, prometheus.GaugeValue, check.Value, server.Server)
+			case strings.HasPrefix(check.Metric, "Process: "):
+				process := strings.TrimPrefix(check.Metric, "Process: ")
+				up := 0.0
+				if check.Status == healthcheck.StatusOK {
+					up = 1
+				}
+				ch <- prometheus.MustNewConstMetric(processUpDesc, prometheus.GaugeValue, up, server.Server, process)
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(sshUpDesc, prometheus.GaugeValue, sshUp, server.Server)
+	}
+}
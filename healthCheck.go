@@ -2,66 +2,93 @@ package healthcheck
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
+	"net"
+	"os"
 	"os/exec"
 	"runtime"
-	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/rs/zerolog"
 	"golang.org/x/crypto/ssh"
 )
 
 // ServerConfig holds configuration for individual server
 type ServerConfig struct {
-	IP        string   // Server IP or hostname
-	Username  string   // SSH username
-	Password  string   // SSH password
-	Processes []string // List of processes to monitor
-	Type      string   // Server type (used for default process selection)
+	IP                   string   // Server IP or hostname
+	Username             string   // SSH username
+	Password             string   // SSH password
+	PrivateKeyPath       string   // Path to an SSH private key file, for key-based auth
+	PrivateKeyPassphrase string   // Passphrase for PrivateKeyPath, if it is encrypted
+	UseAgent             bool     // Authenticate via the running ssh-agent (SSH_AUTH_SOCK)
+	KnownHostsPath       string   // Path to a known_hosts file used to verify the host key
+	Processes            []string // List of processes to monitor
+	Type                 string   // Server type (used for default process selection)
 }
 
 // Config holds health check configuration
 type Config struct {
-	Servers         []ServerConfig      // List of servers to monitor
-	SlackWebhookURL string              // Slack webhook URL
-	ProcessMap      map[string][]string // Default process list by server type
-	Commands        map[string]string   // List of commands to execute
-	Thresholds      map[string]float64  // Warning thresholds
+	Servers          []ServerConfig      // List of servers to monitor
+	ProcessMap       map[string][]string // Default process list by server type
+	Collectors       []MetricCollector   // Metric collectors run against each server
+	Notifiers        []Notifier          // Destinations a completed Report is delivered to
+	Thresholds       map[string]float64  // Warning thresholds, keyed by metric sample name
+	LogLevel         zerolog.Level       // Level for the internal logger, defaults to zerolog.InfoLevel
+	Concurrency      int                 // Max number of servers checked in parallel, defaults to runtime.NumCPU()
+	PerServerTimeout time.Duration       // Deadline for all checks against a single server
+	MaxRetries       int                 // Max SSH dial attempts before giving up on a server
+	RetryBaseDelay   time.Duration       // Base delay for exponential backoff between dial retries
+
+	mu sync.RWMutex // guards the fields above against concurrent reload via Watch
+
+	sshPoolMu sync.Mutex
+	sshPool   map[string]*ssh.Client // keyed by "user@ip:port", reused across RunCheck invocations
 }
 
-// NewDefaultConfig creates a Config with default settings
+// NewDefaultConfig creates a Config with default settings. Unlike the old
+// shell-pipeline implementation, this works on Linux, macOS, and Windows
+// since the default collectors are backed by gopsutil rather than os/exec.
 func NewDefaultConfig() *Config {
-	// Linux system check
-	if runtime.GOOS != "linux" {
-		panic("This program is only supported on Linux operating systems.")
-	}
-
-	return &Config{
-		Servers:         []ServerConfig{},
-		SlackWebhookURL: "",
+	c := &Config{
+		Servers: []ServerConfig{},
 		ProcessMap: map[string][]string{
 			"default": {}, // Empty default value
 		},
-		Commands: map[string]string{
-			"CPU Usage":     "top -bn1 | grep 'Cpu(s)' | awk '{print $2 \"% user, \" $4 \"% system, \" $8 \"% idle\"}'",
-			"Memory Usage":  "free -h | awk 'NR==2{print $2 \" total, \" $3 \" used, \" $4 \" free\"}'",
-			"Disk Usage":    "df -h | awk '$NF==\"/\"{print $2 \" total, \" $3 \" used, \" $5 \" used\"}'",
-			"Network Check": "ping -c 1 8.8.8.8 > /dev/null && echo 'Network is OK' || echo 'Network Issue'",
+		Collectors: []MetricCollector{
+			NewCPUCollector(),
+			NewMemoryCollector(),
+			NewDiskCollector(),
+			NewNetworkCollector(),
 		},
 		Thresholds: map[string]float64{
 			"CPU Idle":    20.0,
 			"Memory Used": 80.0,
 			"Disk Used":   90.0,
 		},
+		LogLevel:         zerolog.InfoLevel,
+		Concurrency:      runtime.NumCPU(),
+		PerServerTimeout: 10 * time.Second,
+		MaxRetries:       3,
+		RetryBaseDelay:   500 * time.Millisecond,
 	}
+	return c
+}
+
+// newLogger builds a console-writer zerolog.Logger at the given level.
+func newLogger(level zerolog.Level) zerolog.Logger {
+	return zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}).
+		Level(level).
+		With().Timestamp().Logger()
 }
 
 // AddServer adds a new server to the configuration
 func (c *Config) AddServer(ip, username, password string, processes []string, serverType string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.Servers = append(c.Servers, ServerConfig{
 		IP:        ip,
 		Username:  username,
@@ -73,31 +100,96 @@ func (c *Config) AddServer(ip, username, password string, processes []string, se
 
 // AddProcessType adds a process list for a server type
 func (c *Config) AddProcessType(typeName string, processes []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.ProcessMap[typeName] = processes
 }
 
-// AddCommand adds a new command or modifies an existing one
+// AddCommand adds a shell command as a MetricCollector, for users migrating
+// from the old Commands map. New code should append to Collectors directly.
 func (c *Config) AddCommand(name, command string) {
-	c.Commands[name] = command
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Collectors = append(c.Collectors, &ShellCollector{Label: name, Command: command})
 }
 
 // SetThreshold sets a warning threshold
 func (c *Config) SetThreshold(name string, value float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	c.Thresholds[name] = value
 }
 
-// RunCheck checks the status of all servers and returns the result
-func (c *Config) RunCheck() string {
-	var report string
+// RunCheck checks the status of all servers and returns the resulting
+// Report. It is equivalent to RunCheckContext(context.Background()).
+func (c *Config) RunCheck() *Report {
+	return c.RunCheckContext(context.Background())
+}
+
+// RunCheckContext checks the status of all servers, fanning out across a
+// worker pool bounded by Config.Concurrency, and returns the resulting
+// Report. For the legacy plain-text string, call Report.Text(). Once built,
+// the report is delivered to every configured Notifier. Checking stops early
+// for any server whose context is cancelled or exceeds PerServerTimeout.
+func (c *Config) RunCheckContext(ctx context.Context) *Report {
+	// Snapshot the server list and run-level settings under a read lock, so a
+	// concurrent Watch reload can't mutate them out from under an in-flight
+	// run. Collectors, thresholds and process lists are read per server (under
+	// the same lock) when that server's check starts.
+	c.mu.RLock()
+	logger := newLogger(c.LogLevel)
+	servers := c.Servers
+	concurrency := c.Concurrency
+	perServerTimeout := c.PerServerTimeout
+	notifiers := c.Notifiers
+	c.mu.RUnlock()
+
+	start := time.Now()
+
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
 
-	for _, server := range c.Servers {
-		fmt.Printf("\n=== Checking status of server: %s ===\n", server.IP)
-		report += fmt.Sprintf("\n=== Checking status of server: %s ===\n", server.IP)
-		report += c.checkStatus(server)
+	results := make([]ServerReport, len(servers))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				server := servers[i]
+				logger.Info().Str("server", server.IP).Msg("checking server status")
+
+				serverCtx := ctx
+				var cancel context.CancelFunc
+				if perServerTimeout > 0 {
+					serverCtx, cancel = context.WithTimeout(ctx, perServerTimeout)
+				}
+				results[i] = c.checkStatusContext(serverCtx, server)
+				if cancel != nil {
+					cancel()
+				}
+			}
+		}()
 	}
 
-	if c.SlackWebhookURL != "" {
-		c.sendReportToSlack(report)
+	for i := range servers {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	report := &Report{Generated: start, Servers: results, Duration: time.Since(start)}
+
+	for _, notifier := range notifiers {
+		if err := notifier.Notify(ctx, report); err != nil {
+			logger.Error().Err(err).Msg("failed to deliver report to notifier")
+		}
 	}
 
 	return report
@@ -110,6 +202,9 @@ func (c *Config) getProcessesForServer(server ServerConfig) []string {
 		return server.Processes
 	}
 
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	// If a server type is specified and there is a process list for that type, use it
 	if server.Type != "" {
 		if processes, ok := c.ProcessMap[server.Type]; ok {
@@ -121,35 +216,164 @@ func (c *Config) getProcessesForServer(server ServerConfig) []string {
 	return c.ProcessMap["default"]
 }
 
-// createSSHClient creates an SSH client
-func createSSHClient(server ServerConfig) (*ssh.Client, error) {
+// createSSHClient dials an SSH client for server, honoring ctx for
+// cancellation during the TCP dial.
+func createSSHClient(ctx context.Context, server ServerConfig) (*ssh.Client, error) {
 	// For localhost, execute commands locally without SSH
-	if server.IP == "localhost" || server.IP == "127.0.0.1" {
+	if isLocalServer(server) {
 		return nil, nil
 	}
 
+	auth, err := buildAuthMethods(server)
+	if err != nil {
+		return nil, err
+	}
+
+	hostKeyCallback, err := buildHostKeyCallback(server)
+	if err != nil {
+		return nil, err
+	}
+
 	config := &ssh.ClientConfig{
-		User: server.Username,
-		Auth: []ssh.AuthMethod{
-			ssh.Password(server.Password),
-		},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		User:            server.Username,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
 		Timeout:         5 * time.Second,
 	}
 
-	client, err := ssh.Dial("tcp", fmt.Sprintf("%s:22", server.IP), config)
+	addr := fmt.Sprintf("%s:22", server.IP)
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect via SSH: %v", err)
+		return nil, fmt.Errorf("failed to connect via SSH: %w", err)
 	}
 
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to connect via SSH: %w", err)
+	}
+
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+// getSSHClient returns a pooled SSH client for server, dialing (with retry)
+// and caching it on first use. Pooled clients are reused across RunCheck
+// invocations instead of re-dialing every cycle; call Close to tear the
+// pool down.
+func (c *Config) getSSHClient(ctx context.Context, server ServerConfig) (*ssh.Client, error) {
+	if isLocalServer(server) {
+		return nil, nil
+	}
+	key := fmt.Sprintf("%s@%s:22", server.Username, server.IP)
+
+	c.sshPoolMu.Lock()
+	client, ok := c.sshPool[key]
+	c.sshPoolMu.Unlock()
+
+	if ok {
+		if sshClientAlive(client) {
+			return client, nil
+		}
+		// The pooled connection died (remote reboot, idle timeout, network
+		// blip); evict it so we redial with retry instead of handing back a
+		// permanently broken client for the rest of the process's life.
+		c.evictSSHClient(key, client)
+	}
+
+	client, err := c.dialSSHWithRetry(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	c.sshPoolMu.Lock()
+	if c.sshPool == nil {
+		c.sshPool = map[string]*ssh.Client{}
+	}
+	c.sshPool[key] = client
+	c.sshPoolMu.Unlock()
+
 	return client, nil
 }
 
-// runCommand executes a command and returns the result
-func runCommand(client *ssh.Client, command string, isLocal bool) (string, error) {
+// sshClientAlive sends a lightweight keepalive request over client's
+// connection to check whether a pooled SSH session is still usable.
+func sshClientAlive(client *ssh.Client) bool {
+	_, _, err := client.SendRequest("keepalive@healthcheck", true, nil)
+	return err == nil
+}
+
+// evictSSHClient removes a dead pooled connection, closing it only if it is
+// still the one cached under key (another goroutine may have already
+// replaced it).
+func (c *Config) evictSSHClient(key string, dead *ssh.Client) {
+	c.sshPoolMu.Lock()
+	if current, ok := c.sshPool[key]; ok && current == dead {
+		delete(c.sshPool, key)
+	}
+	c.sshPoolMu.Unlock()
+	dead.Close()
+}
+
+// Close tears down every pooled SSH connection opened by RunCheck or
+// RunCheckContext.
+func (c *Config) Close() error {
+	c.sshPoolMu.Lock()
+	defer c.sshPoolMu.Unlock()
+
+	var firstErr error
+	for key, client := range c.sshPool {
+		if err := client.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(c.sshPool, key)
+	}
+	return firstErr
+}
+
+// dialSSHWithRetry calls createSSHClient, retrying failed dials with
+// exponential backoff, for at most Config.MaxRetries attempts in all.
+func (c *Config) dialSSHWithRetry(ctx context.Context, server ServerConfig) (*ssh.Client, error) {
+	c.mu.RLock()
+	maxRetries := c.MaxRetries
+	baseDelay := c.RetryBaseDelay
+	c.mu.RUnlock()
+
+	if maxRetries <= 0 {
+		maxRetries = 1
+	}
+	if baseDelay <= 0 {
+		baseDelay = 500 * time.Millisecond
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := baseDelay * time.Duration(1<<uint(attempt-1))
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		client, err := createSSHClient(ctx, server)
+		if err == nil {
+			return client, nil
+		}
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// runCommand executes command on client (or locally, if isLocal), honoring
+// ctx: on the local path exec.CommandContext kills the process, and on the
+// remote path the session is signalled to terminate.
+func runCommand(ctx context.Context, client *ssh.Client, command string, isLocal bool) (string, error) {
 	if isLocal {
 		// Execute local command
-		cmd := exec.Command("sh", "-c", command)
+		cmd := exec.CommandContext(ctx, "sh", "-c", command)
 		output, err := cmd.CombinedOutput()
 		if err != nil {
 			return "", err
@@ -166,121 +390,88 @@ func runCommand(client *ssh.Client, command string, isLocal bool) (string, error
 
 	var stdout bytes.Buffer
 	session.Stdout = &stdout
-	err = session.Run(command)
-	if err != nil {
-		return "", err
-	}
 
-	return strings.TrimSpace(stdout.String()), nil
+	done := make(chan error, 1)
+	go func() { done <- session.Run(command) }()
+
+	select {
+	case <-ctx.Done():
+		session.Signal(ssh.SIGKILL)
+		return "", ctx.Err()
+	case err := <-done:
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(stdout.String()), nil
+	}
 }
 
-// checkStatus checks the status of a specific server
-func (c *Config) checkStatus(server ServerConfig) string {
+// checkStatusContext checks the status of a specific server and returns its
+// ServerReport, honoring ctx's deadline and cancellation.
+func (c *Config) checkStatusContext(ctx context.Context, server ServerConfig) ServerReport {
+	start := time.Now()
 	processes := c.getProcessesForServer(server)
-	var result string
+	report := ServerReport{Server: server.IP}
 
-	// Create SSH client
-	isLocal := server.IP == "localhost" || server.IP == "127.0.0.1"
-	client, err := createSSHClient(server)
-	if err != nil {
-		result += fmt.Sprintf("❌ *SSH Connection:* %s\n", err.Error())
-		return result
-	}
+	c.mu.RLock()
+	collectors := c.Collectors
+	thresholds := c.Thresholds
+	c.mu.RUnlock()
 
-	if !isLocal && client != nil {
-		defer client.Close()
+	// Get a pooled SSH client, dialing with retry on first use
+	isLocal := isLocalServer(server)
+	client, err := c.getSSHClient(ctx, server)
+	if err != nil {
+		report.Checks = append(report.Checks, CheckResult{Metric: "SSH Connection", Status: StatusFail, Err: err})
+		report.Duration = time.Since(start)
+		return report
 	}
 
-	// Execute system status check commands
-	for label, cmdStr := range c.Commands {
-		output, err := runCommand(client, cmdStr, isLocal)
+	// Run each configured metric collector
+	for _, collector := range collectors {
+		checkStart := time.Now()
+		samples, err := collector.Collect(ctx, server, thresholds, c.getSSHClient)
+		duration := time.Since(checkStart)
 		if err != nil {
-			result += fmt.Sprintf("❌ *%s:* Error executing command on %s - %s\n", label, server.IP, err.Error())
+			report.Checks = append(report.Checks, CheckResult{Metric: collector.Name(), Status: StatusFail, Err: err, Duration: duration})
 			continue
 		}
 
-		switch label {
-		case "CPU Usage":
-			idleStr := strings.Split(output, ", ")[2] // "xx% idle"
-			idlePercent, _ := strconv.ParseFloat(strings.TrimSuffix(strings.Fields(idleStr)[0], "%"), 64)
-			if idlePercent <= c.Thresholds["CPU Idle"] {
-				result += fmt.Sprintf("⚠️ *%s:* %s\n", label, output)
-			} else {
-				result += fmt.Sprintf("✅ *%s:* %s\n", label, output)
-			}
-
-		case "Memory Usage":
-			fields := strings.Fields(output) // ["xxGi" "total," "xxGi" "used," "xxGi" "free"]
-			usedStr := strings.TrimSuffix(fields[2], "Gi")
-			totalStr := strings.TrimSuffix(fields[0], "Gi")
-			used, _ := strconv.ParseFloat(usedStr, 64)
-			total, _ := strconv.ParseFloat(totalStr, 64)
-			usagePercent := (used / total) * 100
-			if usagePercent >= c.Thresholds["Memory Used"] {
-				result += fmt.Sprintf("⚠️ *%s:* %s (%.1f%% used)\n", label, output, usagePercent)
-			} else {
-				result += fmt.Sprintf("✅ *%s:* %s (%.1f%% used)\n", label, output, usagePercent)
-			}
-
-		case "Disk Usage":
-			usedStr := strings.Split(output, ", ")[2] // "xx% used"
-			usedPercent, _ := strconv.ParseFloat(strings.TrimSuffix(strings.Fields(usedStr)[0], "%"), 64)
-			if usedPercent >= c.Thresholds["Disk Used"] {
-				result += fmt.Sprintf("⚠️ *%s:* %s\n", label, output)
-			} else {
-				result += fmt.Sprintf("✅ *%s:* %s\n", label, output)
-			}
-
-		default:
-			result += fmt.Sprintf("✅ *%s:* %s\n", label, output)
+		for _, sample := range samples {
+			report.Checks = append(report.Checks, CheckResult{
+				Metric:    sample.Name,
+				Status:    sample.Status,
+				Value:     sample.Value,
+				Unit:      sample.Unit,
+				Threshold: thresholds[sample.Name],
+				Detail:    sample.Detail,
+				Duration:  duration,
+			})
 		}
 	}
 
 	// Check process status
 	if len(processes) > 0 {
 		for _, process := range processes {
+			checkStart := time.Now()
 			cmdStr := fmt.Sprintf("ps aux | grep -v grep | grep '%s'", process)
-			output, err := runCommand(client, cmdStr, isLocal)
+			output, err := runCommand(ctx, client, cmdStr, isLocal)
+			duration := time.Since(checkStart)
+
+			result := CheckResult{Metric: fmt.Sprintf("Process: %s", process), Duration: duration}
 			if err != nil || output == "" {
-				result += fmt.Sprintf("❌ *Process Check:* %s is NOT running\n", process)
+				result.Status = StatusFail
+				result.Detail = fmt.Sprintf("%s is NOT running", process)
 			} else {
-				result += fmt.Sprintf("✅ *Process Check:* %s is running\n", process)
+				result.Status = StatusOK
+				result.Detail = fmt.Sprintf("%s is running", process)
 			}
+			report.Checks = append(report.Checks, result)
 		}
 	} else {
-		result += "ℹ️ *Process Check:* No processes specified for monitoring\n"
-	}
-
-	return result
-}
-
-// sendReportToSlack sends the report to Slack
-func (c *Config) sendReportToSlack(report string) {
-	currentTime := time.Now().Format("2006-01-02 15:04:05")
-	title := fmt.Sprintf("Daily Health Check Report - %s", currentTime)
-
-	payload := map[string]string{
-		"text": fmt.Sprintf("*%s*\n%s", title, report),
-	}
-
-	jsonPayload, err := json.Marshal(payload)
-	if err != nil {
-		fmt.Printf("Error marshalling JSON: %v\n", err)
-		return
+		report.Checks = append(report.Checks, CheckResult{Metric: "Process Check", Status: StatusOK, Detail: "No processes specified for monitoring"})
 	}
 
-	resp, err := http.Post(c.SlackWebhookURL, "application/json", bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		fmt.Printf("Error sending report to Slack: %v\n", err)
-		return
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		fmt.Printf("Slack API error: %s - %s\n", resp.Status, string(body))
-		return
-	}
-
-	fmt.Println("Report sent to Slack successfully!")
+	report.Duration = time.Since(start)
+	return report
 }
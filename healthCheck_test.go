@@ -0,0 +1,296 @@
+package healthcheck
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// fakeSSHServer is a minimal SSH server for exercising pooling and
+// connection-reuse without a real sshd: it accepts any password, answers
+// every "keepalive@healthcheck" global request (as sshClientAlive expects),
+// and replies to exec requests with a canned numeric line so collectors
+// parsing "CPU Idle"/"Memory Used"/"Disk Used" style output succeed.
+type fakeSSHServer struct {
+	listener net.Listener
+	accepts  int32 // number of TCP connections accepted, i.e. distinct SSH dials
+}
+
+func newFakeSSHServer(t *testing.T, addr string) *fakeSSHServer {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate host key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromSigner(priv)
+	if err != nil {
+		t.Fatalf("failed to build host key signer: %v", err)
+	}
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(ssh.ConnMetadata, []byte) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", addr, err)
+	}
+
+	srv := &fakeSSHServer{listener: listener}
+	go srv.acceptLoop(config)
+	t.Cleanup(func() { listener.Close() })
+	return srv
+}
+
+func (s *fakeSSHServer) acceptLoop(config *ssh.ServerConfig) {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		atomic.AddInt32(&s.accepts, 1)
+		go s.serve(conn, config)
+	}
+}
+
+func (s *fakeSSHServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	sConn, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	defer sConn.Close()
+
+	go func() {
+		for req := range reqs {
+			if req.WantReply {
+				req.Reply(true, nil)
+			}
+		}
+	}()
+
+	for newChan := range chans {
+		if newChan.ChannelType() != "session" {
+			newChan.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		channel, requests, err := newChan.Accept()
+		if err != nil {
+			continue
+		}
+		go serveSession(channel, requests)
+	}
+}
+
+func serveSession(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	for req := range requests {
+		if req.Type != "exec" {
+			if req.WantReply {
+				req.Reply(false, nil)
+			}
+			continue
+		}
+		req.Reply(true, nil)
+		channel.Write([]byte("42.0"))
+		channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+		return
+	}
+}
+
+func remoteTestServer(ip string) ServerConfig {
+	return ServerConfig{IP: ip, Username: "test", Password: "test"}
+}
+
+// TestGetSSHClientPoolsConnections asserts that repeated getSSHClient calls
+// for the same server reuse one dialed connection instead of dialing fresh
+// each time.
+func TestGetSSHClientPoolsConnections(t *testing.T) {
+	fake := newFakeSSHServer(t, "127.0.0.2:22")
+	server := remoteTestServer("127.0.0.2")
+
+	c := &Config{MaxRetries: 1, RetryBaseDelay: time.Millisecond}
+	defer c.Close()
+
+	client1, err := c.getSSHClient(context.Background(), server)
+	if err != nil {
+		t.Fatalf("getSSHClient() error: %v", err)
+	}
+	client2, err := c.getSSHClient(context.Background(), server)
+	if err != nil {
+		t.Fatalf("getSSHClient() error: %v", err)
+	}
+
+	if client1 != client2 {
+		t.Error("getSSHClient() dialed a second connection instead of reusing the pooled one")
+	}
+	if got := atomic.LoadInt32(&fake.accepts); got != 1 {
+		t.Errorf("server accepted %d connections, want 1", got)
+	}
+}
+
+// TestGetSSHClientEvictsDeadConnection asserts that once a pooled connection
+// goes stale (its transport closed out from under it), getSSHClient evicts it
+// and redials rather than handing back a permanently broken client.
+func TestGetSSHClientEvictsDeadConnection(t *testing.T) {
+	fake := newFakeSSHServer(t, "127.0.0.3:22")
+	server := remoteTestServer("127.0.0.3")
+
+	c := &Config{MaxRetries: 1, RetryBaseDelay: time.Millisecond}
+	defer c.Close()
+
+	client1, err := c.getSSHClient(context.Background(), server)
+	if err != nil {
+		t.Fatalf("getSSHClient() error: %v", err)
+	}
+	client1.Close() // simulate the remote end dropping the connection
+
+	client2, err := c.getSSHClient(context.Background(), server)
+	if err != nil {
+		t.Fatalf("getSSHClient() error after eviction: %v", err)
+	}
+	if client1 == client2 {
+		t.Error("getSSHClient() returned the dead client instead of redialing")
+	}
+	if got := atomic.LoadInt32(&fake.accepts); got != 2 {
+		t.Errorf("server accepted %d connections, want 2 (initial dial + redial after eviction)", got)
+	}
+}
+
+// TestCollectorsReuseThePooledClient guards against the bug where every
+// built-in collector dialed its own ad-hoc SSH connection instead of
+// routing through Config.getSSHClient: a single checkStatusContext run
+// against a remote-shaped server, with all four built-in collectors plus
+// its process check enabled, must open exactly one SSH connection.
+func TestCollectorsReuseThePooledClient(t *testing.T) {
+	fake := newFakeSSHServer(t, "127.0.0.4:22")
+	server := remoteTestServer("127.0.0.4")
+	server.Processes = []string{"init"}
+
+	c := &Config{
+		Collectors: []MetricCollector{
+			NewCPUCollector(),
+			NewMemoryCollector(),
+			NewDiskCollector(),
+			NewNetworkCollector(),
+		},
+		Thresholds:     map[string]float64{},
+		MaxRetries:     1,
+		RetryBaseDelay: time.Millisecond,
+	}
+	defer c.Close()
+
+	c.checkStatusContext(context.Background(), server)
+
+	if got := atomic.LoadInt32(&fake.accepts); got != 1 {
+		t.Errorf("server accepted %d connections for one checkStatusContext run, want 1 (collectors should share the pooled client)", got)
+	}
+}
+
+// TestDialSSHWithRetryBackoff asserts that dialSSHWithRetry keeps dialing
+// after a failure, making MaxRetries attempts in all with the exponential
+// backoff between them, instead of giving up after the first failure.
+func TestDialSSHWithRetryBackoff(t *testing.T) {
+	// Nothing listens on this loopback address, so every dial attempt fails
+	// fast with connection-refused rather than timing out.
+	server := remoteTestServer("127.0.0.5")
+
+	baseDelay := 20 * time.Millisecond
+	c := &Config{MaxRetries: 3, RetryBaseDelay: baseDelay}
+
+	start := time.Now()
+	_, err := c.dialSSHWithRetry(context.Background(), server)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("dialSSHWithRetry() error = nil, want a dial failure")
+	}
+
+	// Attempts 2 and 3 wait baseDelay and 2*baseDelay respectively; attempt 1
+	// has no wait. Allow slack below the expected sum for scheduling jitter.
+	wantMin := baseDelay + 2*baseDelay
+	if elapsed < wantMin/2 {
+		t.Errorf("dialSSHWithRetry() returned after %v, want at least ~%v (did it actually retry?)", elapsed, wantMin)
+	}
+}
+
+// trackingCollector records the high-water mark of concurrently in-flight
+// Collect calls, to verify RunCheckContext actually fans work out across its
+// worker pool instead of running servers one at a time.
+type trackingCollector struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+}
+
+func (t *trackingCollector) Name() string { return "Tracking" }
+
+func (t *trackingCollector) Collect(ctx context.Context, server ServerConfig, thresholds map[string]float64, getClient SSHClientGetter) ([]MetricSample, error) {
+	t.mu.Lock()
+	t.inFlight++
+	if t.inFlight > t.maxInFlight {
+		t.maxInFlight = t.inFlight
+	}
+	t.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	t.mu.Lock()
+	t.inFlight--
+	t.mu.Unlock()
+
+	return []MetricSample{{Name: "Tracking", Status: StatusOK}}, nil
+}
+
+// TestRunCheckContextConcurrency asserts that RunCheckContext checks servers
+// in parallel up to Config.Concurrency, rather than serially.
+func TestRunCheckContextConcurrency(t *testing.T) {
+	const concurrency = 4
+	const serverCount = 8
+
+	tracker := &trackingCollector{}
+	c := &Config{
+		Collectors:  []MetricCollector{tracker},
+		Thresholds:  map[string]float64{},
+		Concurrency: concurrency,
+	}
+	for i := 0; i < serverCount; i++ {
+		c.Servers = append(c.Servers, ServerConfig{IP: "localhost"})
+	}
+
+	start := time.Now()
+	report := c.RunCheckContext(context.Background())
+	elapsed := time.Since(start)
+
+	if len(report.Servers) != serverCount {
+		t.Fatalf("got %d server reports, want %d", len(report.Servers), serverCount)
+	}
+
+	tracker.mu.Lock()
+	maxInFlight := tracker.maxInFlight
+	tracker.mu.Unlock()
+
+	if maxInFlight <= 1 {
+		t.Errorf("maxInFlight = %d, want > 1 (servers should be checked concurrently)", maxInFlight)
+	}
+	if maxInFlight > concurrency {
+		t.Errorf("maxInFlight = %d, want <= Concurrency (%d)", maxInFlight, concurrency)
+	}
+
+	// Fully serial execution would take serverCount*20ms; bounding by
+	// Concurrency should finish in well under that.
+	serialEstimate := time.Duration(serverCount) * 20 * time.Millisecond
+	if elapsed >= serialEstimate {
+		t.Errorf("RunCheckContext took %v, want well under the serial estimate of %v", elapsed, serialEstimate)
+	}
+}
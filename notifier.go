@@ -0,0 +1,318 @@
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"sync"
+)
+
+// Notifier delivers a Report to an external system, such as a chat webhook
+// or an on-call paging service.
+type Notifier interface {
+	// Notify sends the report, returning an error if delivery fails.
+	Notify(ctx context.Context, report *Report) error
+}
+
+// shouldNotify reports whether a report's severity meets or exceeds min.
+func shouldNotify(report *Report, min Severity) bool {
+	return report.MaxSeverity() >= min
+}
+
+// postJSON POSTs a JSON-encoded payload to url and treats any non-2xx
+// response as an error.
+func postJSON(ctx context.Context, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+	return nil
+}
+
+// SlackNotifier posts a Report to a Slack incoming webhook, using Block Kit
+// sections per server and an attachment color reflecting the worst status
+// found.
+type SlackNotifier struct {
+	WebhookURL  string
+	MinSeverity Severity
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, report *Report) error {
+	if !shouldNotify(report, n.MinSeverity) {
+		return nil
+	}
+
+	counts := report.Counts()
+	color := "good"
+	switch {
+	case counts[StatusFail] > 0:
+		color = "danger"
+	case counts[StatusWarn] > 0:
+		color = "warning"
+	}
+
+	var blocks []map[string]any
+	blocks = append(blocks, map[string]any{
+		"type": "header",
+		"text": map[string]string{"type": "plain_text", "text": "Health Check Report"},
+	})
+	for _, server := range report.Servers {
+		blocks = append(blocks, map[string]any{
+			"type": "section",
+			"text": map[string]string{"type": "mrkdwn", "text": fmt.Sprintf("*%s*\n```%s```", server.Server, renderServerChecks(server))},
+		})
+	}
+
+	payload := map[string]any{
+		"attachments": []map[string]any{
+			{"color": color, "blocks": blocks},
+		},
+	}
+
+	return postJSON(ctx, n.WebhookURL, payload)
+}
+
+// renderServerChecks renders a ServerReport's checks as plain text, used by
+// notifiers that embed the report inline rather than linking out to it.
+func renderServerChecks(server ServerReport) string {
+	var b strings.Builder
+	for _, check := range server.Checks {
+		b.WriteString(renderCheckResult(check))
+	}
+	return b.String()
+}
+
+// DiscordNotifier posts a Report to a Discord webhook as plain message
+// content.
+type DiscordNotifier struct {
+	WebhookURL  string
+	MinSeverity Severity
+}
+
+func (n *DiscordNotifier) Notify(ctx context.Context, report *Report) error {
+	if !shouldNotify(report, n.MinSeverity) {
+		return nil
+	}
+
+	var b strings.Builder
+	b.WriteString("**Health Check Report**\n")
+	for _, server := range report.Servers {
+		fmt.Fprintf(&b, "**%s**\n```%s```\n", server.Server, renderServerChecks(server))
+	}
+
+	payload := map[string]string{"content": b.String()}
+	return postJSON(ctx, n.WebhookURL, payload)
+}
+
+// TeamsNotifier posts a Report to a Microsoft Teams incoming webhook as a
+// legacy MessageCard.
+type TeamsNotifier struct {
+	WebhookURL  string
+	MinSeverity Severity
+}
+
+func (n *TeamsNotifier) Notify(ctx context.Context, report *Report) error {
+	if !shouldNotify(report, n.MinSeverity) {
+		return nil
+	}
+
+	counts := report.Counts()
+	color := "2EB67D" // green
+	switch {
+	case counts[StatusFail] > 0:
+		color = "E01E5A" // red
+	case counts[StatusWarn] > 0:
+		color = "ECB22E" // yellow
+	}
+
+	var sections []map[string]any
+	for _, server := range report.Servers {
+		sections = append(sections, map[string]any{
+			"activityTitle": server.Server,
+			"text":          renderServerChecks(server),
+		})
+	}
+
+	payload := map[string]any{
+		"@type":      "MessageCard",
+		"@context":   "http://schema.org/extensions",
+		"themeColor": color,
+		"summary":    "Health Check Report",
+		"sections":   sections,
+	}
+
+	return postJSON(ctx, n.WebhookURL, payload)
+}
+
+// EmailNotifier sends a Report as an HTML table over SMTP.
+type EmailNotifier struct {
+	Host        string
+	Port        int
+	Username    string
+	Password    string
+	From        string
+	To          []string
+	MinSeverity Severity
+}
+
+func (n *EmailNotifier) Notify(ctx context.Context, report *Report) error {
+	if !shouldNotify(report, n.MinSeverity) {
+		return nil
+	}
+
+	var body strings.Builder
+	body.WriteString("<html><body>")
+	for _, server := range report.Servers {
+		fmt.Fprintf(&body, "<h3>%s</h3><table border=\"1\" cellpadding=\"4\" cellspacing=\"0\">", server.Server)
+		body.WriteString("<tr><th>Metric</th><th>Status</th><th>Value</th><th>Threshold</th></tr>")
+		for _, check := range server.Checks {
+			fmt.Fprintf(&body, "<tr><td>%s</td><td>%s</td><td>%.1f%s</td><td>%.1f</td></tr>",
+				check.Metric, check.Status, check.Value, check.Unit, check.Threshold)
+		}
+		body.WriteString("</table>")
+	}
+	body.WriteString("</body></html>")
+
+	headers := strings.Join([]string{
+		fmt.Sprintf("From: %s", n.From),
+		fmt.Sprintf("To: %s", strings.Join(n.To, ", ")),
+		"Subject: Health Check Report",
+		"MIME-Version: 1.0",
+		"Content-Type: text/html; charset=\"UTF-8\"",
+	}, "\r\n")
+	message := []byte(headers + "\r\n\r\n" + body.String())
+
+	var auth smtp.Auth
+	if n.Username != "" {
+		auth = smtp.PlainAuth("", n.Username, n.Password, n.Host)
+	}
+
+	addr := fmt.Sprintf("%s:%d", n.Host, n.Port)
+	if err := smtp.SendMail(addr, auth, n.From, n.To, message); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// PagerDutyNotifier fires PagerDuty Events API v2 alerts, deduped by
+// "server/metric" key, only on FAIL, and auto-resolving once a check
+// recovers.
+type PagerDutyNotifier struct {
+	RoutingKey  string
+	MinSeverity Severity
+
+	// EventsURL overrides the PagerDuty Events API endpoint; tests point it
+	// at a local httptest.Server. Defaults to pagerDutyEventsURL.
+	EventsURL string
+
+	mu     sync.Mutex
+	active map[string]bool // dedup key -> currently firing
+}
+
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+func (n *PagerDutyNotifier) Notify(ctx context.Context, report *Report) error {
+	n.mu.Lock()
+	if n.active == nil {
+		n.active = map[string]bool{}
+	}
+	n.mu.Unlock()
+
+	seen := map[string]bool{}
+	for _, server := range report.Servers {
+		for _, check := range server.Checks {
+			key := fmt.Sprintf("%s/%s", server.Server, check.Metric)
+
+			n.mu.Lock()
+			wasActive := n.active[key]
+			n.mu.Unlock()
+
+			// MinSeverity filters checks without an active incident only, so
+			// an active one still reaches the recovery case below.
+			if !wasActive && severityForStatus(check.Status) < n.MinSeverity {
+				continue
+			}
+			seen[key] = true
+
+			switch {
+			case check.Status == StatusFail && !wasActive:
+				if err := n.sendEvent(ctx, key, "trigger", fmt.Sprintf("%s: %s is failing", server.Server, check.Metric)); err != nil {
+					return err
+				}
+				n.mu.Lock()
+				n.active[key] = true
+				n.mu.Unlock()
+
+			case check.Status != StatusFail && wasActive:
+				if err := n.sendEvent(ctx, key, "resolve", fmt.Sprintf("%s: %s has recovered", server.Server, check.Metric)); err != nil {
+					return err
+				}
+				n.mu.Lock()
+				delete(n.active, key)
+				n.mu.Unlock()
+			}
+		}
+	}
+
+	// Any key still active that this report didn't touch at all (its server
+	// or collector was removed from config) would otherwise page forever,
+	// since it can only resolve above by reporting a non-FAIL status.
+	n.mu.Lock()
+	var stale []string
+	for key := range n.active {
+		if !seen[key] {
+			stale = append(stale, key)
+		}
+	}
+	n.mu.Unlock()
+
+	for _, key := range stale {
+		if err := n.sendEvent(ctx, key, "resolve", fmt.Sprintf("%s is no longer being checked", key)); err != nil {
+			return err
+		}
+		n.mu.Lock()
+		delete(n.active, key)
+		n.mu.Unlock()
+	}
+
+	return nil
+}
+
+func (n *PagerDutyNotifier) sendEvent(ctx context.Context, dedupKey, action, summary string) error {
+	url := n.EventsURL
+	if url == "" {
+		url = pagerDutyEventsURL
+	}
+
+	payload := map[string]any{
+		"routing_key":  n.RoutingKey,
+		"event_action": action,
+		"dedup_key":    dedupKey,
+		"payload": map[string]any{
+			"summary":  summary,
+			"source":   "healthcheck",
+			"severity": "critical",
+		},
+	}
+	return postJSON(ctx, url, payload)
+}
@@ -0,0 +1,112 @@
+package healthcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestShouldNotify(t *testing.T) {
+	okReport := &Report{Servers: []ServerReport{{Checks: []CheckResult{{Status: StatusOK}}}}}
+	warnReport := &Report{Servers: []ServerReport{{Checks: []CheckResult{{Status: StatusWarn}}}}}
+	failReport := &Report{Servers: []ServerReport{{Checks: []CheckResult{{Status: StatusFail}}}}}
+
+	tests := []struct {
+		name   string
+		report *Report
+		min    Severity
+		want   bool
+	}{
+		{"ok report, min ok", okReport, SeverityOK, true},
+		{"ok report, min warn", okReport, SeverityWarn, false},
+		{"warn report, min warn", warnReport, SeverityWarn, true},
+		{"fail report, min warn", failReport, SeverityWarn, true},
+		{"warn report, min fail", warnReport, SeverityFail, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldNotify(tt.report, tt.min); got != tt.want {
+				t.Errorf("shouldNotify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSlackNotifierRespectsMinSeverity(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	n := &SlackNotifier{WebhookURL: server.URL, MinSeverity: SeverityFail}
+	warnReport := &Report{Servers: []ServerReport{{Server: "s1", Checks: []CheckResult{{Status: StatusWarn}}}}}
+
+	if err := n.Notify(context.Background(), warnReport); err != nil {
+		t.Fatalf("Notify() error: %v", err)
+	}
+	if hits != 0 {
+		t.Errorf("webhook called %d times, want 0 (report severity below MinSeverity)", hits)
+	}
+
+	failReport := &Report{Servers: []ServerReport{{Server: "s1", Checks: []CheckResult{{Status: StatusFail}}}}}
+	if err := n.Notify(context.Background(), failReport); err != nil {
+		t.Fatalf("Notify() error: %v", err)
+	}
+	if hits != 1 {
+		t.Errorf("webhook called %d times, want 1 (report severity meets MinSeverity)", hits)
+	}
+}
+
+// TestPagerDutyNotifierRespectsMinSeverity guards against the bug where
+// PagerDutyNotifier fired on every check regardless of MinSeverity: a report
+// with only a WARN check and MinSeverity set to Fail must never reach the
+// network.
+func TestPagerDutyNotifierRespectsMinSeverity(t *testing.T) {
+	n := &PagerDutyNotifier{RoutingKey: "x", MinSeverity: SeverityFail}
+	warnReport := &Report{Servers: []ServerReport{
+		{Server: "s1", Checks: []CheckResult{{Metric: "CPU Idle", Status: StatusWarn}}},
+	}}
+
+	if err := n.Notify(context.Background(), warnReport); err != nil {
+		t.Fatalf("Notify() error: %v", err)
+	}
+	if len(n.active) != 0 {
+		t.Errorf("active = %v, want empty (WARN check should be filtered out by MinSeverity=Fail)", n.active)
+	}
+}
+
+// TestPagerDutyNotifierAutoResolvesDroppedCheck guards against the bug where
+// an active incident whose key stopped appearing in the report at all (its
+// server or collector was removed from config) stayed active forever,
+// because the only resolve path required the same key to report a non-FAIL
+// status again.
+func TestPagerDutyNotifierAutoResolvesDroppedCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	n := &PagerDutyNotifier{RoutingKey: "x", MinSeverity: SeverityFail, EventsURL: server.URL}
+	failReport := &Report{Servers: []ServerReport{
+		{Server: "s1", Checks: []CheckResult{{Metric: "CPU Idle", Status: StatusFail}}},
+	}}
+
+	if err := n.Notify(context.Background(), failReport); err != nil {
+		t.Fatalf("Notify() error: %v", err)
+	}
+	if !n.active["s1/CPU Idle"] {
+		t.Fatalf("active = %v, want s1/CPU Idle active after a FAIL report", n.active)
+	}
+
+	emptyReport := &Report{Servers: []ServerReport{{Server: "s1", Checks: nil}}}
+	if err := n.Notify(context.Background(), emptyReport); err != nil {
+		t.Fatalf("Notify() error: %v", err)
+	}
+	if len(n.active) != 0 {
+		t.Errorf("active = %v, want empty once the check is dropped from the report", n.active)
+	}
+}
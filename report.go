@@ -0,0 +1,182 @@
+package healthcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CheckResult is the outcome of a single metric or process check against one
+// server.
+type CheckResult struct {
+	Metric    string        // e.g. "CPU Idle", "Process: nginx"
+	Status    MetricStatus  // OK, WARN, or FAIL
+	Value     float64       // measured value, if numeric
+	Unit      string        // e.g. "%"
+	Threshold float64       // threshold the value was compared against, if any
+	Detail    string        // free-form text, e.g. ShellCollector output or "nginx is running"
+	Err       error         // non-nil if the check itself failed to run
+	Duration  time.Duration // how long the check took
+}
+
+// MarshalJSON renders Err as a plain string, since the error interface has
+// no exported fields for encoding/json to marshal on its own.
+func (c CheckResult) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Metric    string        `json:"metric"`
+		Status    MetricStatus  `json:"status"`
+		Value     float64       `json:"value"`
+		Unit      string        `json:"unit"`
+		Threshold float64       `json:"threshold"`
+		Detail    string        `json:"detail,omitempty"`
+		Err       string        `json:"err,omitempty"`
+		Duration  time.Duration `json:"duration"`
+	}
+
+	a := alias{
+		Metric:    c.Metric,
+		Status:    c.Status,
+		Value:     c.Value,
+		Unit:      c.Unit,
+		Threshold: c.Threshold,
+		Detail:    c.Detail,
+		Duration:  c.Duration,
+	}
+	if c.Err != nil {
+		a.Err = c.Err.Error()
+	}
+	return json.Marshal(a)
+}
+
+// ServerReport aggregates all CheckResults collected for one server.
+type ServerReport struct {
+	Server   string
+	Checks   []CheckResult
+	Duration time.Duration
+}
+
+// Report aggregates the ServerReports produced by a single RunCheck
+// invocation.
+type Report struct {
+	Servers   []ServerReport
+	Generated time.Time
+	Duration  time.Duration
+}
+
+// Severity ranks a Report's overall outcome so Notifiers can filter by
+// MinSeverity.
+type Severity int
+
+const (
+	SeverityOK Severity = iota
+	SeverityWarn
+	SeverityFail
+)
+
+// severityForStatus maps a single CheckResult's MetricStatus to the Severity
+// scale Notifiers filter on, so per-check gating (PagerDutyNotifier) can
+// reuse the same MinSeverity semantics as per-report gating (shouldNotify).
+func severityForStatus(status MetricStatus) Severity {
+	switch status {
+	case StatusFail:
+		return SeverityFail
+	case StatusWarn:
+		return SeverityWarn
+	default:
+		return SeverityOK
+	}
+}
+
+// MaxSeverity returns the most severe status found across all checks in the
+// report.
+func (r *Report) MaxSeverity() Severity {
+	sev := SeverityOK
+	for _, server := range r.Servers {
+		for _, check := range server.Checks {
+			switch check.Status {
+			case StatusFail:
+				return SeverityFail
+			case StatusWarn:
+				if sev < SeverityWarn {
+					sev = SeverityWarn
+				}
+			}
+		}
+	}
+	return sev
+}
+
+// Counts returns the number of checks in each status across the whole
+// report, keyed by MetricStatus.
+func (r *Report) Counts() map[MetricStatus]int {
+	counts := map[MetricStatus]int{}
+	for _, server := range r.Servers {
+		for _, check := range server.Checks {
+			counts[check.Status]++
+		}
+	}
+	return counts
+}
+
+// Text renders the report as the plain, emoji-prefixed text format the
+// module has always produced.
+func (r *Report) Text() string {
+	var b strings.Builder
+	for _, server := range r.Servers {
+		fmt.Fprintf(&b, "\n=== Checking status of server: %s ===\n", server.Server)
+		for _, check := range server.Checks {
+			b.WriteString(renderCheckResult(check))
+		}
+	}
+	return b.String()
+}
+
+// JSON renders the report as indented JSON, suitable for piping into log
+// aggregators or other programmatic consumers.
+func (r *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Markdown renders the report as a Markdown document with one section per
+// server and a table of check results.
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	for _, server := range r.Servers {
+		fmt.Fprintf(&b, "## %s\n\n", server.Server)
+		b.WriteString("| Metric | Status | Value | Threshold |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, check := range server.Checks {
+			if check.Err != nil {
+				fmt.Fprintf(&b, "| %s | %s | - | - |\n", check.Metric, check.Err.Error())
+				continue
+			}
+			fmt.Fprintf(&b, "| %s | %s | %.1f%s | %.1f |\n", check.Metric, check.Status, check.Value, check.Unit, check.Threshold)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderCheckResult formats a CheckResult as a single emoji-prefixed report
+// line, matching the module's historical text output.
+func renderCheckResult(check CheckResult) string {
+	if check.Err != nil {
+		return fmt.Sprintf("❌ *%s:* %s\n", check.Metric, check.Err.Error())
+	}
+
+	icon := "✅"
+	switch {
+	case check.Metric == "Process Check":
+		icon = "ℹ️"
+	case check.Status == StatusWarn:
+		icon = "⚠️"
+	case check.Status == StatusFail:
+		icon = "❌"
+	}
+
+	if check.Detail != "" {
+		return fmt.Sprintf("%s *%s:* %s\n", icon, check.Metric, check.Detail)
+	}
+	return fmt.Sprintf("%s *%s:* %.1f%s\n", icon, check.Metric, check.Value, check.Unit)
+}
@@ -0,0 +1,83 @@
+package healthcheck
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReportMaxSeverity(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []CheckResult
+		want   Severity
+	}{
+		{"empty", nil, SeverityOK},
+		{"all ok", []CheckResult{{Status: StatusOK}}, SeverityOK},
+		{"warn only", []CheckResult{{Status: StatusOK}, {Status: StatusWarn}}, SeverityWarn},
+		{"fail wins", []CheckResult{{Status: StatusWarn}, {Status: StatusFail}}, SeverityFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &Report{Servers: []ServerReport{{Server: "s1", Checks: tt.checks}}}
+			if got := report.MaxSeverity(); got != tt.want {
+				t.Errorf("MaxSeverity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeverityForStatus(t *testing.T) {
+	tests := []struct {
+		status MetricStatus
+		want   Severity
+	}{
+		{StatusOK, SeverityOK},
+		{StatusWarn, SeverityWarn},
+		{StatusFail, SeverityFail},
+	}
+
+	for _, tt := range tests {
+		if got := severityForStatus(tt.status); got != tt.want {
+			t.Errorf("severityForStatus(%v) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestReportCounts(t *testing.T) {
+	report := &Report{Servers: []ServerReport{
+		{Server: "s1", Checks: []CheckResult{{Status: StatusOK}, {Status: StatusWarn}}},
+		{Server: "s2", Checks: []CheckResult{{Status: StatusFail}}},
+	}}
+
+	counts := report.Counts()
+	if counts[StatusOK] != 1 || counts[StatusWarn] != 1 || counts[StatusFail] != 1 {
+		t.Errorf("Counts() = %v, want one of each status", counts)
+	}
+}
+
+func TestReportTextJSONMarkdown(t *testing.T) {
+	report := &Report{Servers: []ServerReport{
+		{Server: "s1", Checks: []CheckResult{
+			{Metric: "CPU Idle", Status: StatusWarn, Value: 12.3, Unit: "%", Threshold: 20},
+		}},
+	}}
+
+	text := report.Text()
+	if !strings.Contains(text, "s1") || !strings.Contains(text, "CPU Idle") {
+		t.Errorf("Text() missing expected content: %q", text)
+	}
+
+	data, err := report.JSON()
+	if err != nil {
+		t.Fatalf("JSON() error: %v", err)
+	}
+	if !strings.Contains(string(data), `"metric": "CPU Idle"`) {
+		t.Errorf("JSON() missing expected content: %s", data)
+	}
+
+	md := report.Markdown()
+	if !strings.Contains(md, "## s1") || !strings.Contains(md, "| CPU Idle |") {
+		t.Errorf("Markdown() missing expected content: %q", md)
+	}
+}
@@ -0,0 +1,89 @@
+package healthcheck
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// buildAuthMethods assembles the SSH auth methods to try for server, in
+// order: password, private key, and ssh-agent. At least one of Password,
+// PrivateKeyPath, or UseAgent must be set.
+func buildAuthMethods(server ServerConfig) ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+
+	if server.Password != "" {
+		methods = append(methods, ssh.Password(server.Password))
+	}
+
+	if server.PrivateKeyPath != "" {
+		signer, err := loadPrivateKey(server.PrivateKeyPath, server.PrivateKeyPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load private key %s: %w", server.PrivateKeyPath, err)
+		}
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+
+	if server.UseAgent {
+		signers, err := agentSigners()
+		if err != nil {
+			return nil, fmt.Errorf("failed to use SSH agent: %w", err)
+		}
+		methods = append(methods, ssh.PublicKeysCallback(func() ([]ssh.Signer, error) { return signers, nil }))
+	}
+
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no SSH auth method configured for %s (set Password, PrivateKeyPath, or UseAgent)", server.IP)
+	}
+
+	return methods, nil
+}
+
+// loadPrivateKey reads and parses a private key file, decrypting it with
+// passphrase if it is encrypted.
+func loadPrivateKey(path, passphrase string) (ssh.Signer, error) {
+	key, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if passphrase != "" {
+		return ssh.ParsePrivateKeyWithPassphrase(key, []byte(passphrase))
+	}
+	return ssh.ParsePrivateKey(key)
+}
+
+// agentSigners connects to the running ssh-agent over SSH_AUTH_SOCK and
+// returns its available signers.
+func agentSigners() ([]ssh.Signer, error) {
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return nil, fmt.Errorf("SSH_AUTH_SOCK is not set")
+	}
+
+	conn, err := net.Dial("unix", sock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ssh-agent: %w", err)
+	}
+
+	return agent.NewClient(conn).Signers()
+}
+
+// buildHostKeyCallback returns a HostKeyCallback backed by the known_hosts
+// file at server.KnownHostsPath. If no path is configured, it falls back to
+// ssh.InsecureIgnoreHostKey(), which is unsafe for production fleets.
+func buildHostKeyCallback(server ServerConfig) (ssh.HostKeyCallback, error) {
+	if server.KnownHostsPath == "" {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+
+	callback, err := knownhosts.New(server.KnownHostsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load known_hosts file %s: %w", server.KnownHostsPath, err)
+	}
+	return callback, nil
+}